@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+
+	"github.com/geforce6t/get-rich-with-go/simulator"
+)
+
+// batchStats summarizes a distribution of per-run values as mean, standard
+// deviation and a handful of percentiles.
+type batchStats struct {
+	Mean    float64
+	StdDev  float64
+	P50     float64
+	P90     float64
+	P99     float64
+}
+
+// summarize computes a batchStats over values. It sorts its argument
+// in place.
+func summarize(values []float64) batchStats {
+	n := len(values)
+	if n == 0 {
+		return batchStats{}
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(n)
+
+	var sumSquares float64
+	for _, v := range values {
+		d := v - mean
+		sumSquares += d * d
+	}
+	stdDev := math.Sqrt(sumSquares / float64(n))
+
+	sort.Float64s(values)
+	percentile := func(p float64) float64 {
+		idx := int(p * float64(n-1))
+		return values[idx]
+	}
+
+	return batchStats{
+		Mean:   mean,
+		StdDev: stdDev,
+		P50:    percentile(0.50),
+		P90:    percentile(0.90),
+		P99:    percentile(0.99),
+	}
+}
+
+// runBatch runs cfg.Batch independent simulations concurrently, using a
+// worker pool sized to runtime.NumCPU(), and prints aggregated statistics
+// over their final Gini coefficient, top-1% wealth share and time to
+// condensation. Each run gets its own *rand.Rand, seeded deterministically
+// from cfg.Seed so that a batch is itself reproducible, which is the
+// reason RunOnce and everything it calls take an explicit rng instead of
+// using the math/rand global.
+func runBatch(cfg Config) {
+	// Validate -rule once, upfront: an invalid name would otherwise only
+	// surface when simulator.RunOnce panics inside a worker goroutine,
+	// crashing the whole process instead of failing cleanly.
+	if _, err := simulator.NewTradeRule(cfg.Rule, cfg.Config); err != nil {
+		log.Fatalln(err)
+	}
+
+	seedRng := rand.New(rand.NewSource(cfg.Seed))
+	seeds := make([]int64, cfg.Batch)
+	for i := range seeds {
+		seeds[i] = seedRng.Int63()
+	}
+
+	jobs := make(chan int64)
+	results := make(chan simulator.Result)
+
+	workers := runtime.NumCPU()
+	if workers > cfg.Batch {
+		workers = cfg.Batch
+	}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for seed := range jobs {
+				rng := rand.New(rand.NewSource(seed))
+				results <- simulator.RunOnce(cfg.Config, rng)
+			}
+		}()
+	}
+	go func() {
+		for _, seed := range seeds {
+			jobs <- seed
+		}
+		close(jobs)
+	}()
+
+	ginis := make([]float64, 0, cfg.Batch)
+	top1Shares := make([]float64, 0, cfg.Batch)
+	condensationTimes := make([]float64, 0, cfg.Batch)
+	for i := 0; i < cfg.Batch; i++ {
+		r := <-results
+		ginis = append(ginis, r.FinalGini)
+		top1Shares = append(top1Shares, r.Top1Share)
+		if r.TimeToCondensation >= 0 {
+			condensationTimes = append(condensationTimes, float64(r.TimeToCondensation))
+		}
+	}
+
+	fmt.Printf("runs: %d\n", cfg.Batch)
+	printStats("final Gini", summarize(ginis))
+	printStats("top-1% share", summarize(top1Shares))
+	if len(condensationTimes) == 0 {
+		fmt.Println("time to condensation: no run reached >50% held by one agent")
+	} else {
+		fmt.Printf("time to condensation: %d/%d runs condensed\n", len(condensationTimes), cfg.Batch)
+		printStats("time to condensation", summarize(condensationTimes))
+	}
+}
+
+func printStats(label string, s batchStats) {
+	fmt.Printf("%s: mean=%.4f stddev=%.4f p50=%.4f p90=%.4f p99=%.4f\n",
+		label, s.Mean, s.StdDev, s.P50, s.P90, s.P99)
+}
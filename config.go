@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/geforce6t/get-rich-with-go/simulator"
+)
+
+// Config holds every parameter of a run of this program: the simulation
+// parameters themselves (embedded from simulator.Config), plus the
+// driver-level choices of how the run is seeded and presented.
+type Config struct {
+	simulator.Config
+
+	// Seed is the seed for the random number generator.
+	Seed int64
+	// Headless, when set, skips the termui dashboard and instead streams
+	// per-round snapshots to stdout.
+	Headless bool
+	// Format selects the headless output encoding: "csv" or "json".
+	Format string
+	// FullVector, when set, includes the full wealth vector in each
+	// headless snapshot instead of just the summary statistics.
+	FullVector bool
+	// Batch, when greater than zero, runs that many independent
+	// simulations concurrently instead of a single interactive or
+	// headless run, and prints aggregated statistics.
+	Batch int
+}
+
+// parseFlags populates a Config from the command-line flags, defaulting to
+// the values the simulation used to hardcode.
+func parseFlags() Config {
+	var cfg Config
+	flag.IntVar(&cfg.NumOfAgents, "agents", 10, "number of agents in the market")
+	flag.Float64Var(&cfg.InitialWealth, "initial-wealth", 100.0, "initial wealth per agent")
+	flag.IntVar(&cfg.Rounds, "rounds", 10000, "number of trades to simulate")
+	flag.Float64Var(&cfg.PercentGain, "percent-gain", 0.20, "percentage of own wealth the poorer agent gains on a win")
+	flag.Float64Var(&cfg.PercentLoss, "percent-loss", 0.17, "percentage of own wealth the poorer agent loses on a loss")
+	flag.Float64Var(&cfg.Delta, "delta", 0.0, "Affine Wealth Model Wealth-Attained Advantage bias")
+	flag.Float64Var(&cfg.Chi, "chi", 0.0, "Affine Wealth Model redistribution rate")
+	flag.IntVar(&cfg.RedistributionInterval, "redistribution-interval", 100, "trades between redistribution steps")
+	flag.Float64Var(&cfg.Lambda, "savings", 0.0, "savings propensity set aside by the savings-propensity rule")
+	flag.StringVar(&cfg.Rule, "rule", "affine-wealth", fmt.Sprintf("trade rule to use (%s)", strings.Join(simulator.RuleNames(), ", ")))
+	flag.Int64Var(&cfg.Seed, "seed", time.Now().UnixNano(), "random seed, for reproducible runs")
+	flag.BoolVar(&cfg.Headless, "headless", false, "run without the terminal UI, streaming snapshots to stdout")
+	flag.StringVar(&cfg.Format, "format", "csv", "headless output format: csv or json")
+	flag.BoolVar(&cfg.FullVector, "full-vector", false, "include the full wealth vector in headless snapshots")
+	flag.IntVar(&cfg.Batch, "batch", 0, "run N simulations concurrently and print aggregated statistics")
+	flag.Parse()
+
+	// These fields feed straight into unguarded arithmetic (picking a
+	// receiver distinct from the sender, dividing into the redistribution
+	// interval), so a bad value here must be rejected now rather than
+	// panicking or hanging once the simulation starts.
+	if cfg.NumOfAgents < 2 {
+		log.Fatalf("-agents must be at least 2, got %d", cfg.NumOfAgents)
+	}
+	if cfg.RedistributionInterval <= 0 {
+		log.Fatalf("-redistribution-interval must be positive, got %d", cfg.RedistributionInterval)
+	}
+
+	return cfg
+}
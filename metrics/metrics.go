@@ -0,0 +1,94 @@
+// Package metrics computes inequality statistics over a population of
+// agent wealths, for display alongside the wealth-distribution bar chart.
+package metrics
+
+import "sort"
+
+// Gini computes the Gini coefficient of the given wealths using the
+// sorted-wealth formula G = (2*Σ i*w_i)/(n*Σ w_i) - (n+1)/n, where i runs
+// from 1 to n over the ascending-sorted wealths. This runs in O(n log n),
+// dominated by the sort.
+func Gini(wealths []float64) float64 {
+	n := len(wealths)
+	if n == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, n)
+	copy(sorted, wealths)
+	sort.Float64s(sorted)
+
+	var weightedSum, total float64
+	for i, w := range sorted {
+		weightedSum += float64(i+1) * w
+		total += w
+	}
+	if total == 0 {
+		return 0
+	}
+	return (2*weightedSum)/(float64(n)*total) - float64(n+1)/float64(n)
+}
+
+// Lorenz computes the Lorenz curve of the given wealths: the cumulative
+// share of total wealth held by the poorest i/n of the population, for i
+// from 1 to n. The returned slice ends in 1.0, except when the total wealth
+// is zero, in which case every entry is 0; an empty input yields nil.
+func Lorenz(wealths []float64) []float64 {
+	n := len(wealths)
+	if n == 0 {
+		return nil
+	}
+
+	sorted := make([]float64, n)
+	copy(sorted, wealths)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, w := range sorted {
+		total += w
+	}
+
+	curve := make([]float64, n)
+	if total == 0 {
+		return curve
+	}
+	var cumulative float64
+	for i, w := range sorted {
+		cumulative += w
+		curve[i] = cumulative / total
+	}
+	return curve
+}
+
+// TopShare returns the fraction of total wealth held by the richest `pct`
+// share of the population (e.g. pct=0.01 for the top 1%). At least one
+// agent is always included, so this is well-defined even for small n.
+func TopShare(wealths []float64, pct float64) float64 {
+	n := len(wealths)
+	if n == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, n)
+	copy(sorted, wealths)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, w := range sorted {
+		total += w
+	}
+	if total == 0 {
+		return 0
+	}
+
+	count := int(float64(n) * pct)
+	if count < 1 {
+		count = 1
+	}
+
+	var topSum float64
+	for _, w := range sorted[n-count:] {
+		topSum += w
+	}
+	return topSum / total
+}
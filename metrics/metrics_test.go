@@ -0,0 +1,43 @@
+package metrics
+
+import "testing"
+
+func TestGiniPerfectEquality(t *testing.T) {
+	wealths := []float64{100, 100, 100, 100}
+	if g := Gini(wealths); g > 1e-9 {
+		t.Fatalf("expected Gini of 0 for perfect equality, got %v", g)
+	}
+}
+
+func TestGiniMaximalInequality(t *testing.T) {
+	wealths := []float64{0, 0, 0, 400}
+	g := Gini(wealths)
+	want := 1 - 1.0/float64(len(wealths))
+	if diff := g - want; diff > 1e-9 || diff < -1e-9 {
+		t.Fatalf("expected Gini of %v for one agent holding everything, got %v", want, g)
+	}
+}
+
+func TestTopShareWinnerTakeAll(t *testing.T) {
+	wealths := []float64{0, 0, 0, 400}
+	if s := TopShare(wealths, 0.25); s != 1.0 {
+		t.Fatalf("expected top 25%% share of 1.0 when one agent holds everything, got %v", s)
+	}
+}
+
+func TestLorenzEndsAtOne(t *testing.T) {
+	wealths := []float64{10, 40, 20, 30}
+	curve := Lorenz(wealths)
+	if len(curve) != len(wealths) {
+		t.Fatalf("expected Lorenz curve of length %d, got %d", len(wealths), len(curve))
+	}
+	last := curve[len(curve)-1]
+	if diff := last - 1.0; diff > 1e-9 || diff < -1e-9 {
+		t.Fatalf("expected Lorenz curve to end at 1.0, got %v", last)
+	}
+	for i := 1; i < len(curve); i++ {
+		if curve[i] < curve[i-1] {
+			t.Fatalf("Lorenz curve must be non-decreasing, got %v before %v", curve[i-1], curve[i])
+		}
+	}
+}
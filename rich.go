@@ -5,85 +5,52 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
-	"time"
 
 	ui "github.com/gizak/termui"
 	"github.com/gizak/termui/widgets"
-)
 
-const (
-	// Number of agents in the market
-	numOfAgents = 10
-	// Initial amount of money that each agent owns
-	initialWealth = 100.0
-	// How many trades to simulate
-	rounds = 10000
-	// If the poorer agent gains wealth it is this percentage of their total wealth.
-	percentGain = 0.20
-	// If the poorer agent loses wealth, it is this percentage of their total wealth.
-	percentLoss = 0.17
+	"github.com/geforce6t/get-rich-with-go/metrics"
+	"github.com/geforce6t/get-rich-with-go/simulator"
 )
 
-// Agents are defined by the amount of money, or wealth, they have.
-type agents []float64
-
-// pickTwoRandomAgents generates two random numbers `sender` and `receiver` between 0 and numOfAgents-1
-// and ensures that `sender` and `receiver` are not equal. (After all, agents would not trade with themselves.)
-// Note the use of named return values that saves an extra declaration of `receiver` outside the loop
-// (to avoid that `receiver` exists only in the scope of the loop).
-func pickTwoRandomAgents() (sender, receiver int) {
-	sender = rand.Intn(numOfAgents)
-	receiver = sender
-
-	// Generate a random`receiver`. Repeat until `receiver` != `sender`
-	for receiver == sender {
-		receiver = rand.Intn(numOfAgents)
-	}
-	return sender, receiver
-}
-
-// The trading formula assumes that agents sometimes pay either more or less than the traded good is worth.
-// Because of this, wealth flows from one agent to another.
-// As both agents `sender`, `receiver` were already chosen randomly, we can decide at this point that agent `sender` always loses
-// wealth, and agent `receiver` always gains wealth in this transaction.
-// Note: the agents
-func trade(a agents, sender, receiver int) {
-	// Wealth flows from sender to `receiver` in this transaction.
-	// The amount that flows from sender to `receiver` is always a given percentage of the poorer agent.
-
-	// If`receiver` is the poorer agent, the gain is `percentGain` of `receiver`'s total wealth.
-	transfer := a[receiver] * percentGain
-
-	// If `sender` is the poorer agent, the loss is `percentLoss` of `sender`'s total wealth.
-	if a[sender] < a[receiver] {
-		transfer = a[sender] * percentLoss
-	}
-	// It's a deal!
-	a[sender] -= transfer
-	a[receiver] += transfer
+// dashboard groups all the widgets drawn each round, so that new metrics
+// can be added without changing the signature of drawChart.
+type dashboard struct {
+	bc     *widgets.BarChart
+	lorenz *widgets.Plot
+	gini   *widgets.Paragraph
 }
 
-// Draw a bar chart of the current wealth of all agents
-func drawChart(a agents, bc *widgets.BarChart) {
-	bc.Data = a
+// Draw the bar chart of current agent wealth, plus the Lorenz curve and
+// Gini coefficient of the current wealth distribution.
+func drawChart(cfg Config, a simulator.Agents, d *dashboard) {
+	d.bc.Data = a
 	// Scale the bar chart dynamically, to better see
 	// the distribution when the current maximum wealth is
 	// much smaller than the maximum possible wealth.
-	maxPossibleWealth := initialWealth * numOfAgents
+	maxPossibleWealth := cfg.InitialWealth * float64(cfg.NumOfAgents)
 	currentMaxWealth, _ := ui.GetMaxFloat64FromSlice(a)
-	bc.MaxVal = currentMaxWealth + (maxPossibleWealth-currentMaxWealth)*0.05
-	ui.Render(bc)
+	d.bc.MaxVal = currentMaxWealth + (maxPossibleWealth-currentMaxWealth)*0.05
+
+	lorenzCurve := metrics.Lorenz(a)
+	d.lorenz.Data = [][]float64{lorenzCurve}
+
+	gini := metrics.Gini(a)
+	d.gini.Text = fmt.Sprintf("Gini coefficient: %.3f", gini)
+
+	ui.Render(d.bc, d.lorenz, d.gini)
 }
 
-// Run the simulation
-func run(a agents, bc *widgets.BarChart, done <-chan struct{}) {
-	for n := 0; n < rounds; n++ {
-		// Pick two different agents.
-		sender, receiver := pickTwoRandomAgents()
-		// Have them do a trade.
-		trade(a, sender, receiver)
+// Run the simulation, redrawing the dashboard after every trade. rule
+// decides the outcome of each trade and rng supplies all of the run's
+// randomness, so that the interactive, headless and batch modes are all
+// driven by the same simulator.Step primitive without touching the
+// math/rand global.
+func run(cfg Config, a simulator.Agents, rule simulator.TradeRule, rng *rand.Rand, d *dashboard, done <-chan struct{}) {
+	for n := 0; n < cfg.Rounds; n++ {
+		simulator.Step(cfg.Config, a, rule, rng, n)
 		// Update the chart
-		drawChart(a, bc)
+		drawChart(cfg, a, d)
 		// Try to read a value from channel `done`.
 		// The read shall not block, hence it is enclosed in a
 		// select block with a default clause.
@@ -99,19 +66,37 @@ func run(a agents, bc *widgets.BarChart, done <-chan struct{}) {
 func main() {
 	// Setup
 
-	// Pre-allocate the slice, to avoid allocations during the simulation
-	a := make(agents, numOfAgents)
+	cfg := parseFlags()
 
-	// Set a random seed
-	rand.Seed(time.Now().UnixNano())
+	if cfg.Batch > 0 {
+		runBatch(cfg)
+		return
+	}
 
+	rule, err := simulator.NewTradeRule(cfg.Rule, cfg.Config)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Each run gets its own *rand.Rand seeded from cfg.Seed instead of
+	// reseeding the math/rand global, so that the interactive, headless
+	// and batch modes all share the same concurrency-safe entry points.
+	rng := rand.New(rand.NewSource(cfg.Seed))
+
+	// Pre-allocate the slice, to avoid allocations during the simulation
+	a := make(simulator.Agents, cfg.NumOfAgents)
 	for i := range a {
 		// All agents start with the same amount of money.
-		a[i] = initialWealth
+		a[i] = cfg.InitialWealth
+	}
+
+	if cfg.Headless {
+		runHeadless(cfg, a, rule, rng)
+		return
 	}
 
 	// UI setup. `gizak/termui` makes rendering a bar chart in a terminal super easy.
-	err := ui.Init()
+	err = ui.Init()
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -119,14 +104,27 @@ func main() {
 	bc := widgets.NewBarChart()
 	bc.Title = "Agents' Wealth"
 	bc.BarWidth = 5
-	bc.SetRect(5, 5, 10+(bc.BarWidth+1)*numOfAgents, 25)
+	bc.SetRect(5, 5, 10+(bc.BarWidth+1)*cfg.NumOfAgents, 25)
 	bc.LabelStyles = []ui.Style{ui.NewStyle(ui.ColorBlue)}
 	bc.NumStyles = []ui.Style{ui.NewStyle(ui.ColorBlack)}
 	bc.NumFormatter = func(n float64) string {
 		return fmt.Sprintf("%3.1f", n)
 	}
+
+	lorenz := widgets.NewPlot()
+	lorenz.Title = "Lorenz Curve"
+	lorenz.SetRect(5, 26, 10+(bc.BarWidth+1)*cfg.NumOfAgents, 46)
+	lorenz.AxesColor = ui.ColorWhite
+	lorenz.LineColors = []ui.Color{ui.ColorGreen}
+
+	gini := widgets.NewParagraph()
+	gini.Title = "Inequality"
+	gini.SetRect(5, 47, 10+(bc.BarWidth+1)*cfg.NumOfAgents, 50)
+
+	d := &dashboard{bc: bc, lorenz: lorenz, gini: gini}
+
 	// Start rendering.
-	ui.Render(bc)
+	ui.Render(d.bc, d.lorenz, d.gini)
 
 	// `termui` has its own event polling.
 	// We use this here to watch for a key press
@@ -144,9 +142,9 @@ func main() {
 	}(done)
 
 	// Start the simulation!
-	run(a, bc, done)
+	run(cfg, a, rule, rng, d, done)
 
 	// After the simulation, wait for a key press
 	// so that the final chart remains visible.
 	<-done
-}
\ No newline at end of file
+}
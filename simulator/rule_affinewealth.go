@@ -0,0 +1,45 @@
+package simulator
+
+import "math/rand"
+
+// AffineWealthRule implements Boghosian's Affine Wealth Model: it trades
+// the same way as YardSaleRule, except the winner is not chosen by a fair
+// coin flip. The bias parameter Delta skews the win probability in favor
+// of whichever agent is already richer, with Delta == 0 reducing to the
+// Yard Sale model. The model's other half, the redistribution term that
+// counteracts the condensation Delta produces, is applied separately by
+// Redistribute once every RedistributionInterval trades, since it acts on
+// the whole population rather than on a single pair.
+type AffineWealthRule struct {
+	PercentGain float64
+	PercentLoss float64
+	Delta       float64
+}
+
+// Apply picks a winner biased by Delta, then transfers PercentGain or
+// PercentLoss of the poorer agent's wealth from loser to winner.
+func (r AffineWealthRule) Apply(a Agents, sender, receiver int, rng *rand.Rand) {
+	// winProbability is the probability that `receiver` wins the transaction.
+	winProbability := 0.5 + r.Delta*(a[receiver]-a[sender])/Mean(a)
+	winner, loser := receiver, sender
+	if rng.Float64() >= winProbability {
+		winner, loser = sender, receiver
+	}
+
+	transfer := a[winner] * r.PercentGain
+	if a[loser] < a[winner] {
+		transfer = a[loser] * r.PercentLoss
+	}
+	a[loser] -= transfer
+	a[winner] += transfer
+}
+
+func init() {
+	RegisterRule("affine-wealth", func(cfg Config) TradeRule {
+		return AffineWealthRule{
+			PercentGain: cfg.PercentGain,
+			PercentLoss: cfg.PercentLoss,
+			Delta:       cfg.Delta,
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package simulator
+
+import "math/rand"
+
+// KineticExchangeRule implements the Chakraborti-Chakrabarti kinetic
+// exchange model: the transferred amount is epsilon*(w_i+w_j)/2 - w_i,
+// with epsilon uniform on [0,1], so the pair's wealth is redrawn at
+// random rather than bounded by a fixed percentage of either agent's
+// wealth the way YardSaleRule and AffineWealthRule are.
+type KineticExchangeRule struct{}
+
+// Apply redraws the sender and receiver's combined wealth by a random
+// fraction epsilon.
+func (KineticExchangeRule) Apply(a Agents, sender, receiver int, rng *rand.Rand) {
+	epsilon := rng.Float64()
+	transfer := epsilon*(a[sender]+a[receiver])/2 - a[sender]
+	a[sender] += transfer
+	a[receiver] -= transfer
+}
+
+func init() {
+	RegisterRule("kinetic-exchange", func(cfg Config) TradeRule {
+		return KineticExchangeRule{}
+	})
+}
@@ -0,0 +1,32 @@
+package simulator
+
+import "math/rand"
+
+// SavingsPropensityRule implements the Chatterjee-Chakrabarti-Manna
+// savings propensity model: before the random kinetic exchange, every
+// agent first sets aside a fraction Lambda of their own wealth, which
+// never enters the trade. Only the unsaved remainder is pooled and
+// randomly re-split between sender and receiver. A nonzero Lambda
+// produces a Gamma-like steady-state wealth distribution, unlike the
+// pure exponential that Lambda == 0 (plain kinetic exchange) produces.
+type SavingsPropensityRule struct {
+	Lambda float64
+}
+
+// Apply sets aside Lambda of each agent's wealth, then randomly re-splits
+// what remains between sender and receiver.
+func (r SavingsPropensityRule) Apply(a Agents, sender, receiver int, rng *rand.Rand) {
+	savedSender := r.Lambda * a[sender]
+	savedReceiver := r.Lambda * a[receiver]
+	pool := (a[sender] - savedSender) + (a[receiver] - savedReceiver)
+
+	epsilon := rng.Float64()
+	a[sender] = savedSender + epsilon*pool
+	a[receiver] = savedReceiver + (1-epsilon)*pool
+}
+
+func init() {
+	RegisterRule("savings-propensity", func(cfg Config) TradeRule {
+		return SavingsPropensityRule{Lambda: cfg.Lambda}
+	})
+}
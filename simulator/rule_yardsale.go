@@ -0,0 +1,38 @@
+package simulator
+
+import "math/rand"
+
+// YardSaleRule implements the original, unbiased Yard Sale model: the
+// winner of each trade is chosen by a fair coin flip, and the loser
+// always gives up a fixed percentage of the poorer agent's wealth.
+type YardSaleRule struct {
+	PercentGain float64
+	PercentLoss float64
+}
+
+// Apply picks a winner with even odds, then transfers PercentGain or
+// PercentLoss of the poorer agent's wealth from loser to winner.
+func (r YardSaleRule) Apply(a Agents, sender, receiver int, rng *rand.Rand) {
+	winner, loser := receiver, sender
+	if rng.Float64() >= 0.5 {
+		winner, loser = sender, receiver
+	}
+
+	// If `winner` is the poorer agent, the gain is PercentGain of
+	// `winner`'s total wealth.
+	transfer := a[winner] * r.PercentGain
+
+	// If `loser` is the poorer agent, the loss is PercentLoss of
+	// `loser`'s total wealth.
+	if a[loser] < a[winner] {
+		transfer = a[loser] * r.PercentLoss
+	}
+	a[loser] -= transfer
+	a[winner] += transfer
+}
+
+func init() {
+	RegisterRule("yard-sale", func(cfg Config) TradeRule {
+		return YardSaleRule{PercentGain: cfg.PercentGain, PercentLoss: cfg.PercentLoss}
+	})
+}
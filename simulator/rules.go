@@ -0,0 +1,53 @@
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// TradeRule computes the wealth transfer for a single trade between two
+// agents, in place on a. Package simulator ships several TradeRules, each
+// modeling a different econophysics exchange model; RunOnce and any other
+// driver pick one by name via NewTradeRule instead of hardcoding a
+// particular trading formula.
+type TradeRule interface {
+	Apply(a Agents, sender, receiver int, rng *rand.Rand)
+}
+
+// ruleFactories holds one constructor per registered TradeRule, keyed by
+// the name passed to NewTradeRule (and, in the CLI, to -rule). Rules
+// register themselves from their own file's init function, so that
+// adding a new exchange model never requires editing this file.
+var ruleFactories = map[string]func(cfg Config) TradeRule{}
+
+// RegisterRule adds a named TradeRule constructor to the registry. It
+// panics on a duplicate name, since that can only happen from a
+// programming mistake at init time, not from user input.
+func RegisterRule(name string, factory func(cfg Config) TradeRule) {
+	if _, exists := ruleFactories[name]; exists {
+		panic(fmt.Sprintf("simulator: trade rule %q registered twice", name))
+	}
+	ruleFactories[name] = factory
+}
+
+// NewTradeRule builds the named TradeRule from cfg, or returns an error
+// if no rule was registered under that name.
+func NewTradeRule(name string, cfg Config) (TradeRule, error) {
+	factory, ok := ruleFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown trade rule %q (available: %v)", name, RuleNames())
+	}
+	return factory(cfg), nil
+}
+
+// RuleNames returns the names of all registered TradeRules, sorted, for
+// use in flag usage strings and error messages.
+func RuleNames() []string {
+	names := make([]string, 0, len(ruleFactories))
+	for name := range ruleFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,36 @@
+package simulator
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// BenchmarkRules runs one full simulation per registered TradeRule, all
+// from the same seed and population, and reports each rule's
+// steady-state Gini coefficient via `go test -bench . -benchmem`, so
+// that the rules can be compared side by side.
+func BenchmarkRules(b *testing.B) {
+	baseCfg := Config{
+		NumOfAgents:            500,
+		InitialWealth:          100.0,
+		Rounds:                 50000,
+		PercentGain:            0.20,
+		PercentLoss:            0.17,
+		RedistributionInterval: 100,
+		Lambda:                 0.5,
+	}
+
+	for _, name := range RuleNames() {
+		name := name
+		b.Run(name, func(b *testing.B) {
+			cfg := baseCfg
+			cfg.Rule = name
+
+			var result Result
+			for i := 0; i < b.N; i++ {
+				result = RunOnce(cfg, rand.New(rand.NewSource(42)))
+			}
+			b.ReportMetric(result.FinalGini, "gini")
+		})
+	}
+}
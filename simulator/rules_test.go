@@ -0,0 +1,60 @@
+package simulator
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestRuleRegistryHasBuiltins verifies that every built-in rule described
+// in the package documentation registered itself successfully.
+func TestRuleRegistryHasBuiltins(t *testing.T) {
+	want := []string{"affine-wealth", "kinetic-exchange", "savings-propensity", "yard-sale"}
+	got := RuleNames()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d registered rules, got %d: %v", len(want), len(got), got)
+	}
+	for _, name := range want {
+		if _, err := NewTradeRule(name, Config{PercentGain: 0.20, PercentLoss: 0.17}); err != nil {
+			t.Errorf("expected rule %q to be registered, got error: %v", name, err)
+		}
+	}
+}
+
+// TestNewTradeRuleUnknownName verifies that an unregistered rule name is
+// reported as an error rather than silently producing a nil TradeRule.
+func TestNewTradeRuleUnknownName(t *testing.T) {
+	if _, err := NewTradeRule("does-not-exist", Config{}); err == nil {
+		t.Fatal("expected an error for an unregistered rule name")
+	}
+}
+
+// TestKineticExchangeConservesPairWealth verifies that a kinetic exchange
+// trade only moves wealth between sender and receiver, never creating or
+// destroying it.
+func TestKineticExchangeConservesPairWealth(t *testing.T) {
+	a := Agents{100, 200}
+	before := a[0] + a[1]
+
+	KineticExchangeRule{}.Apply(a, 0, 1, rand.New(rand.NewSource(1)))
+
+	after := a[0] + a[1]
+	const tolerance = 1e-9
+	if diff := after - before; diff > tolerance || diff < -tolerance {
+		t.Fatalf("kinetic exchange changed combined wealth: before=%v after=%v", before, after)
+	}
+}
+
+// TestSavingsPropensityConservesPairWealth verifies that setting aside
+// savings before the exchange still conserves the pair's combined wealth.
+func TestSavingsPropensityConservesPairWealth(t *testing.T) {
+	a := Agents{100, 200}
+	before := a[0] + a[1]
+
+	SavingsPropensityRule{Lambda: 0.3}.Apply(a, 0, 1, rand.New(rand.NewSource(1)))
+
+	after := a[0] + a[1]
+	const tolerance = 1e-9
+	if diff := after - before; diff > tolerance || diff < -tolerance {
+		t.Fatalf("savings-propensity exchange changed combined wealth: before=%v after=%v", before, after)
+	}
+}
@@ -0,0 +1,151 @@
+// Package simulator implements the trading models, the per-trade Step that
+// the interactive, headless and batch modes all build on, and the
+// single-run RunOnce that the batch Monte Carlo mode uses. Every function here takes its randomness as an explicit
+// *rand.Rand rather than relying on the math/rand global, so that many
+// runs can be driven concurrently without sharing state.
+package simulator
+
+import (
+	"math/rand"
+
+	"github.com/geforce6t/get-rich-with-go/metrics"
+)
+
+// Config holds every parameter of a single simulation run.
+type Config struct {
+	// NumOfAgents is the number of agents in the market.
+	NumOfAgents int
+	// InitialWealth is the amount of money that each agent starts with.
+	InitialWealth float64
+	// Rounds is how many trades to simulate.
+	Rounds int
+	// PercentGain is the percentage of their own wealth that the poorer
+	// agent gains when they win a trade.
+	PercentGain float64
+	// PercentLoss is the percentage of their own wealth that the poorer
+	// agent loses when they lose a trade.
+	PercentLoss float64
+	// Delta is the Affine Wealth Model's Wealth-Attained Advantage bias.
+	Delta float64
+	// Chi is the Affine Wealth Model's redistribution rate.
+	Chi float64
+	// RedistributionInterval is how many trades occur between
+	// redistribution steps.
+	RedistributionInterval int
+	// Lambda is the savings propensity that SavingsPropensityRule sets
+	// aside from each agent before the random exchange.
+	Lambda float64
+	// Rule selects the registered TradeRule that PickTwoRandomAgents'
+	// picks are fed into each trade. RunOnce defaults it to "affine-wealth"
+	// when empty, preserving the model every caller used before TradeRule
+	// existed; NewTradeRule itself rejects an empty name.
+	Rule string
+}
+
+// Agents are defined by the amount of money, or wealth, they have.
+type Agents []float64
+
+// Mean returns the arithmetic mean wealth of the population.
+func Mean(a Agents) float64 {
+	var sum float64
+	for _, w := range a {
+		sum += w
+	}
+	return sum / float64(len(a))
+}
+
+// PickTwoRandomAgents generates two random numbers `sender` and `receiver`
+// between 0 and cfg.NumOfAgents-1 and ensures that `sender` and `receiver`
+// are not equal. (After all, agents would not trade with themselves.)
+// Note the use of named return values that saves an extra declaration of
+// `receiver` outside the loop (to avoid that `receiver` exists only in the
+// scope of the loop).
+func PickTwoRandomAgents(cfg Config, rng *rand.Rand) (sender, receiver int) {
+	sender = rng.Intn(cfg.NumOfAgents)
+	receiver = sender
+
+	// Generate a random `receiver`. Repeat until `receiver` != `sender`
+	for receiver == sender {
+		receiver = rng.Intn(cfg.NumOfAgents)
+	}
+	return sender, receiver
+}
+
+// Redistribute applies a flat wealth tax at rate `chi`, redistributing the
+// proceeds uniformly across the population: w_i -= chi*(w_i-<w>). This is
+// the Affine Wealth Model's redistribution term, and counteracts the wealth
+// condensation that an unredistributed, biased Yard Sale model produces.
+func Redistribute(a Agents, chi float64) {
+	meanWealth := Mean(a)
+	for i := range a {
+		a[i] -= chi * (a[i] - meanWealth)
+	}
+}
+
+// Step performs a single trade of the simulation: it picks two distinct
+// agents, lets rule decide the outcome of their trade, and applies the
+// redistribution tax every cfg.RedistributionInterval trades. n is the
+// 0-based index of this trade within the run, and decides whether this
+// trade falls on a redistribution round. Step is the one place the
+// per-trade sequence is implemented, so that RunOnce and every driver
+// built on top of it (interactive, headless, batch) can't drift out of
+// sync with one another.
+func Step(cfg Config, a Agents, rule TradeRule, rng *rand.Rand, n int) {
+	sender, receiver := PickTwoRandomAgents(cfg, rng)
+	rule.Apply(a, sender, receiver, rng)
+	if cfg.Chi > 0 && n%cfg.RedistributionInterval == 0 {
+		Redistribute(a, cfg.Chi)
+	}
+}
+
+// Result summarizes the outcome of a single RunOnce call.
+type Result struct {
+	// FinalGini is the Gini coefficient of the wealth distribution after
+	// the last round.
+	FinalGini float64
+	// Top1Share is the fraction of total wealth held by the richest 1% of
+	// agents after the last round.
+	Top1Share float64
+	// TimeToCondensation is the round at which a single agent first held
+	// more than half of all wealth, or -1 if that never happened.
+	TimeToCondensation int
+}
+
+// RunOnce runs a single, complete simulation to cfg.Rounds trades using
+// rng for all randomness, and summarizes the final wealth distribution.
+// The batch Monte Carlo mode builds each of its runs on it; the
+// interactive and headless modes do not call RunOnce, they call Step
+// directly once per trade. Trades are driven by cfg.Rule
+// (defaulting to "affine-wealth", the model every caller used before
+// TradeRule existed); an unregistered rule name is a configuration bug,
+// so it panics rather than returning a zero Result.
+func RunOnce(cfg Config, rng *rand.Rand) Result {
+	name := cfg.Rule
+	if name == "" {
+		name = "affine-wealth"
+	}
+	rule, err := NewTradeRule(name, cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	a := make(Agents, cfg.NumOfAgents)
+	for i := range a {
+		a[i] = cfg.InitialWealth
+	}
+
+	timeToCondensation := -1
+	for n := 0; n < cfg.Rounds; n++ {
+		Step(cfg, a, rule, rng, n)
+
+		if timeToCondensation == -1 && metrics.TopShare(a, 1.0/float64(cfg.NumOfAgents)) > 0.5 {
+			timeToCondensation = n
+		}
+	}
+
+	return Result{
+		FinalGini:          metrics.Gini(a),
+		Top1Share:          metrics.TopShare(a, 0.01),
+		TimeToCondensation: timeToCondensation,
+	}
+}